@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// NewOpaqueID returns a random hex identifier suitable for correlating a
+// WebAuthn ceremony's begin and finish requests.
+func NewOpaqueID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// NewVerificationToken returns an opaque, high-entropy token plus the
+// SHA-256 hash that is safe to persist in place of the plaintext — the same
+// scheme NewRefreshToken uses, since a verification link is just as much a
+// bearer secret as a refresh token.
+func NewVerificationToken() (plain string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	plain = base64.RawURLEncoding.EncodeToString(buf)
+	return plain, HashVerificationToken(plain), nil
+}
+
+func HashVerificationToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
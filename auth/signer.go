@@ -0,0 +1,88 @@
+// Package auth mints and verifies the access/refresh token pair used by
+// AuthHandler, keeping JWT and refresh-token crypto out of the handler layer.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims are the JWT access token claims; Subject carries the user's hex
+// ObjectID so handlers never need to re-derive it from a DAO lookup.
+type Claims struct {
+	jwt.RegisteredClaims
+}
+
+type Signer struct {
+	key        []byte
+	method     jwt.SigningMethod
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewSigner(key []byte, algorithm string, accessTTL, refreshTTL time.Duration) (*Signer, error) {
+	method := jwt.GetSigningMethod(algorithm)
+	if method == nil {
+		return nil, errors.New("auth: unknown signing algorithm: " + algorithm)
+	}
+	return &Signer{
+		key:        key,
+		method:     method,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}, nil
+}
+
+func (s *Signer) RefreshTTL() time.Duration {
+	return s.refreshTTL
+}
+
+// IssueAccessToken returns a short-lived JWT bound to userID.
+func (s *Signer) IssueAccessToken(userID string) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTTL)),
+		},
+	}
+	token := jwt.NewWithClaims(s.method, claims)
+	return token.SignedString(s.key)
+}
+
+// ParseAccessToken validates signature and expiry and returns the claims.
+func (s *Signer) ParseAccessToken(raw string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		return s.key, nil
+	}, jwt.WithValidMethods([]string{s.method.Alg()}))
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// NewRefreshToken returns an opaque, high-entropy refresh token plus the
+// SHA-256 hash that is safe to persist in place of the plaintext.
+func (s *Signer) NewRefreshToken() (plain string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	plain = base64.RawURLEncoding.EncodeToString(buf)
+	return plain, HashRefreshToken(plain), nil
+}
+
+func HashRefreshToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
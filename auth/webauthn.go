@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/knuls/bennu/models"
+)
+
+// NewWebAuthn builds the relying-party config shared by registration and
+// assertion ceremonies.
+func NewWebAuthn(rpID, rpDisplayName string, rpOrigins []string) (*webauthn.WebAuthn, error) {
+	return webauthn.New(&webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: rpDisplayName,
+		RPOrigins:     rpOrigins,
+	})
+}
+
+// WebAuthnUser adapts a models.User and its registered credentials to the
+// webauthn.User interface expected by go-webauthn/webauthn.
+type WebAuthnUser struct {
+	user        *models.User
+	credentials []*models.Credential
+}
+
+func NewWebAuthnUser(user *models.User, credentials []*models.Credential) *WebAuthnUser {
+	return &WebAuthnUser{user: user, credentials: credentials}
+}
+
+func (u *WebAuthnUser) WebAuthnID() []byte {
+	return []byte(u.user.ID.Hex())
+}
+
+func (u *WebAuthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *WebAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Email
+}
+
+func (u *WebAuthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+func (u *WebAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, len(u.credentials))
+	for i, c := range u.credentials {
+		transports := make([]protocol.AuthenticatorTransport, len(c.Transports))
+		for j, t := range c.Transports {
+			transports[j] = protocol.AuthenticatorTransport(t)
+		}
+		credentials[i] = webauthn.Credential{
+			ID:        c.CredentialID,
+			PublicKey: c.PublicKey,
+			Transport: transports,
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    c.AAGUID,
+				SignCount: c.SignCount,
+			},
+		}
+	}
+	return credentials
+}
+
+// ToModelCredential converts a freshly-registered webauthn.Credential into the shape
+// persisted by dao.CredentialDao.
+func ToModelCredential(cred *webauthn.Credential) *models.Credential {
+	transports := make([]string, len(cred.Transport))
+	for i, t := range cred.Transport {
+		transports[i] = string(t)
+	}
+	return &models.Credential{
+		CredentialID: cred.ID,
+		PublicKey:    cred.PublicKey,
+		AAGUID:       cred.Authenticator.AAGUID,
+		SignCount:    cred.Authenticator.SignCount,
+		Transports:   transports,
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,9 +13,12 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
+	"github.com/knuls/bennu/auth"
 	"github.com/knuls/bennu/dao"
 	"github.com/knuls/bennu/handlers"
-	"github.com/knuls/horus/logger"
+	"github.com/knuls/bennu/mail"
+	bennumw "github.com/knuls/bennu/middlewares"
+	"github.com/knuls/bennu/service"
 	"github.com/knuls/horus/middlewares"
 	"github.com/knuls/horus/validator"
 	"github.com/spf13/viper"
@@ -28,6 +32,14 @@ type Config struct {
 	Store    StoreConfig
 	Server   ServerConfig
 	Security SecurityConfig
+	WebAuthn WebAuthnConfig
+	Mail     MailConfig
+	Log      LogConfig
+}
+
+type LogConfig struct {
+	Format string // "json" (default) or "text"
+	Level  string // debug, info, warn, error
 }
 
 type ServiceConfig struct {
@@ -59,16 +71,63 @@ type SecurityConfig struct {
 		Headers []string
 	}
 	AllowCredentials bool
+	JWT              JWTConfig
 }
 
-func main() {
-	// logger
-	log, err := logger.New()
-	if err != nil {
-		fmt.Printf("logger new error: %v", err)
-		os.Exit(1)
+type JWTConfig struct {
+	SigningKey string
+	Algorithm  string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+type WebAuthnConfig struct {
+	RPID          string
+	RPDisplayName string
+	RPOrigins     []string
+}
+
+type MailConfig struct {
+	Driver string // "smtp" (default) or "log"
+	From   string
+	SMTP   SMTPConfig
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+func newMailer(cfg MailConfig, log *slog.Logger) mail.Mailer {
+	if cfg.Driver == "log" {
+		return mail.NewLogMailer(log)
 	}
-	defer log.GetLogger().Sync()
+	return mail.NewSMTPMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.From)
+}
+
+func newLogger(cfg LogConfig) *slog.Logger {
+	level := slog.LevelInfo
+	_ = level.UnmarshalText([]byte(cfg.Level))
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+	return slog.New(handler)
+}
+
+func fatal(log *slog.Logger, msg string, err error) {
+	log.Error(msg, slog.Any("err", err))
+	os.Exit(1)
+}
+
+func main() {
+	// bootstrap logger, reconfigured once config is loaded
+	log := newLogger(LogConfig{})
 
 	// config
 	c := viper.New()
@@ -92,19 +151,34 @@ func main() {
 	c.BindEnv("security.allowed.methods")
 	c.BindEnv("security.allowed.headers")
 	c.BindEnv("security.allowCredentials")
+	c.BindEnv("security.jwt.signingKey")
+	c.BindEnv("security.jwt.algorithm")
+	c.BindEnv("security.jwt.accessTTL")
+	c.BindEnv("security.jwt.refreshTTL")
+	c.BindEnv("webauthn.rpid")
+	c.BindEnv("webauthn.rpDisplayName")
+	c.BindEnv("webauthn.rpOrigins")
+	c.BindEnv("mail.driver")
+	c.BindEnv("mail.from")
+	c.BindEnv("mail.smtp.host")
+	c.BindEnv("mail.smtp.port")
+	c.BindEnv("mail.smtp.username")
+	c.BindEnv("mail.smtp.password")
+	c.BindEnv("log.format")
+	c.BindEnv("log.level")
 	c.AutomaticEnv()
 	var cfg Config
 	if err := c.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			log.Fatalf("config file not found error: %v", err)
+			fatal(log, "config file not found error", err)
 		} else {
-			log.Fatalf("config file read error: %v", err)
+			fatal(log, "config file read error", err)
 		}
 	}
-	err = c.Unmarshal(&cfg)
-	if err != nil {
-		log.Fatalf("config decode error: %v", err)
+	if err := c.Unmarshal(&cfg); err != nil {
+		fatal(log, "config decode error", err)
 	}
+	log = newLogger(cfg.Log)
 
 	// db
 	dbCtx, cancel := context.WithTimeout(context.Background(), cfg.Store.Timeout*time.Second)
@@ -112,17 +186,17 @@ func main() {
 	uri := fmt.Sprintf("%s://%s:%d", cfg.Store.Client, cfg.Store.Host, cfg.Store.Port)
 	client, err := mongo.Connect(dbCtx, options.Client().ApplyURI(uri))
 	if err != nil {
-		log.Fatalf("db connect error: %v", err)
+		fatal(log, "db connect error", err)
 	}
 	defer func() {
-		if err = client.Disconnect(context.Background()); err != nil {
-			log.Fatalf("db disconnect error: %v", err)
+		if err := client.Disconnect(context.Background()); err != nil {
+			fatal(log, "db disconnect error", err)
 		}
 	}()
 	pingCtx, cancel := context.WithTimeout(context.Background(), cfg.Store.Timeout*time.Second)
 	defer cancel()
-	if err = client.Ping(pingCtx, readpref.Primary()); err != nil {
-		log.Fatalf("db ping error: %v", err)
+	if err := client.Ping(pingCtx, readpref.Primary()); err != nil {
+		fatal(log, "db ping error", err)
 	}
 
 	// mux
@@ -139,28 +213,51 @@ func main() {
 	mux.Use(middlewares.RealIP)
 	mux.Use(middlewares.RequestID)
 	mux.Use(middlewares.Recoverer)
-	mux.Use(middlewares.Logger(log))
+	mux.Use(bennumw.RequestLogger(log))
 
 	// validator
 	v, err := validator.New()
 	if err != nil {
-		log.Fatalf("validator new error: %s", err.Error())
+		fatal(log, "validator new error", err)
 	}
 
 	// factory
 	db := client.Database(cfg.Store.Name)
 	factory := dao.NewFactory(db, v)
 
+	// signer
+	signer, err := auth.NewSigner(
+		[]byte(cfg.Security.JWT.SigningKey),
+		cfg.Security.JWT.Algorithm,
+		cfg.Security.JWT.AccessTTL*time.Second,
+		cfg.Security.JWT.RefreshTTL*time.Second,
+	)
+	if err != nil {
+		fatal(log, "signer new error", err)
+	}
+
+	// webauthn
+	wa, err := auth.NewWebAuthn(cfg.WebAuthn.RPID, cfg.WebAuthn.RPDisplayName, cfg.WebAuthn.RPOrigins)
+	if err != nil {
+		fatal(log, "webauthn new error", err)
+	}
+
+	// mailer
+	mailer := newMailer(cfg.Mail, log)
+
+	// provider
+	provider := service.NewProvider(log, v, factory, client, signer, wa, mailer)
+
 	// handlers
-	mux.Mount("/user", handlers.NewUserHandler(log, factory).Routes())
-	mux.Mount("/organization", handlers.NewOrganizationHandler(log, factory).Routes())
-	mux.Mount("/auth", handlers.NewAuthHandler(log, v, db).Routes())
+	mux.Mount("/user", handlers.NewUserHandler(provider).Routes())
+	mux.Mount("/organization", handlers.NewOrganizationHandler(provider).Routes())
+	mux.Mount("/auth", handlers.NewAuthHandler(provider).Routes())
 
 	// server
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Service.Port),
 		Handler:      mux,
-		ErrorLog:     log.GetStdLogger(),
+		ErrorLog:     slog.NewLogLogger(log.Handler(), slog.LevelError),
 		ReadTimeout:  cfg.Server.Timeout.Read * time.Second,
 		WriteTimeout: cfg.Server.Timeout.Write * time.Second,
 		IdleTimeout:  cfg.Server.Timeout.Idle * time.Second,
@@ -169,21 +266,21 @@ func main() {
 	// listen
 	go func() {
 		if err := srv.ListenAndServe(); err != nil {
-			log.Fatalf("listen and serve error: %s", err.Error())
+			fatal(log, "listen and serve error", err)
 		}
 	}()
-	log.Infof("starting %s service on port: %d", cfg.Service.Name, cfg.Service.Port)
+	log.Info("starting service", slog.String("name", cfg.Service.Name), slog.Int("port", cfg.Service.Port))
 
 	// shutdown
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 	sig := <-sigCh
-	log.Infof("signal: %s", sig.String())
+	log.Info("signal received", slog.String("signal", sig.String()))
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.Timeout.Shutdown*time.Second)
 	defer cancel()
 	err = srv.Shutdown(shutdownCtx)
 	if err != nil {
-		log.Fatalf("shutdown error: %s", err.Error())
+		fatal(log, "shutdown error", err)
 	}
 }
@@ -0,0 +1,114 @@
+// Command bennuctl is an operator CLI for tasks that have no HTTP
+// equivalent, starting with bootstrapping the first admin user on a fresh
+// install (there is no way to promote a user through the API until one
+// exists).
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/knuls/bennu/dao"
+	"github.com/knuls/bennu/models"
+	"github.com/knuls/horus/validator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func main() {
+	root := &cobra.Command{Use: "bennuctl"}
+	root.AddCommand(newAuthCmd())
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newAuthCmd() *cobra.Command {
+	authCmd := &cobra.Command{Use: "auth"}
+	userCmd := &cobra.Command{Use: "user"}
+	authCmd.AddCommand(userCmd)
+	userCmd.AddCommand(newAuthUserAddCmd())
+	return authCmd
+}
+
+func newAuthUserAddCmd() *cobra.Command {
+	var email, password string
+	var admin bool
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Create a user directly in Mongo, bypassing the HTTP API",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return addUser(cmd.Context(), email, password, admin)
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "user email")
+	cmd.Flags().StringVar(&password, "password", "", "user password")
+	cmd.Flags().BoolVar(&admin, "admin", false, "grant the admin role")
+	cmd.MarkFlagRequired("email")
+	cmd.MarkFlagRequired("password")
+	return cmd
+}
+
+func addUser(ctx context.Context, email, password string, admin bool) error {
+	c := viper.New()
+	c.AddConfigPath(".")
+	c.SetConfigName("config")
+	c.SetConfigType("yaml")
+	c.SetEnvPrefix("bennu")
+	c.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	c.BindEnv("store.client")
+	c.BindEnv("store.host")
+	c.BindEnv("store.port")
+	c.BindEnv("store.timeout")
+	c.BindEnv("store.name")
+	c.AutomaticEnv()
+	if err := c.ReadInConfig(); err != nil {
+		return fmt.Errorf("config read error: %w", err)
+	}
+
+	host := c.GetString("store.host")
+	port := c.GetInt("store.port")
+	uri := fmt.Sprintf("%s://%s:%d", c.GetString("store.client"), host, port)
+	timeout := c.GetDuration("store.timeout") * time.Second
+
+	dbCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	client, err := mongo.Connect(dbCtx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return fmt.Errorf("db connect error: %w", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	v, err := validator.New()
+	if err != nil {
+		return fmt.Errorf("validator new error: %w", err)
+	}
+	factory := dao.NewFactory(client.Database(c.GetString("store.name")), v)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+	if err != nil {
+		return fmt.Errorf("hash password error: %w", err)
+	}
+	now := time.Now()
+	user := &models.User{
+		Email:     email,
+		Password:  string(hash),
+		Verified:  true,
+		Admin:     admin,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	id, err := factory.GetUserDao().Create(ctx, user)
+	if err != nil {
+		return fmt.Errorf("create user error: %w", err)
+	}
+	fmt.Printf("created user %s (%s)\n", id, email)
+	return nil
+}
@@ -0,0 +1,51 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/knuls/bennu/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const challengesCollection = "challenges"
+
+const challengeTTL = 5 * time.Minute
+
+type ChallengeDao interface {
+	Create(ctx context.Context, challenge *models.Challenge) error
+	FindByID(ctx context.Context, id string) (*models.Challenge, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type challengeDao struct {
+	collection *mongo.Collection
+}
+
+func (d *challengeDao) Create(ctx context.Context, challenge *models.Challenge) error {
+	challenge.ExpiresAt = time.Now().Add(challengeTTL)
+	_, err := d.collection.InsertOne(ctx, challenge)
+	return err
+}
+
+func (d *challengeDao) FindByID(ctx context.Context, id string) (*models.Challenge, error) {
+	var challenge models.Challenge
+	where := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+	if err := d.collection.FindOne(ctx, where).Decode(&challenge); err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+func (d *challengeDao) Delete(ctx context.Context, id string) error {
+	_, err := d.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	return err
+}
+
+func newChallengeDao(db *mongo.Database) *challengeDao {
+	return &challengeDao{collection: db.Collection(challengesCollection)}
+}
@@ -0,0 +1,65 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/knuls/bennu/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const credentialsCollection = "credentials"
+
+type CredentialDao interface {
+	Create(ctx context.Context, credential *models.Credential) (string, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]*models.Credential, error)
+	FindByCredentialID(ctx context.Context, credentialID []byte) (*models.Credential, error)
+	UpdateSignCount(ctx context.Context, id primitive.ObjectID, signCount uint32) error
+}
+
+type credentialDao struct {
+	collection *mongo.Collection
+}
+
+func (d *credentialDao) Create(ctx context.Context, credential *models.Credential) (string, error) {
+	res, err := d.collection.InsertOne(ctx, credential)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (d *credentialDao) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]*models.Credential, error) {
+	cursor, err := d.collection.Find(ctx, bson.D{{Key: "userId", Value: userID}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	credentials := []*models.Credential{}
+	if err := cursor.All(ctx, &credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+func (d *credentialDao) FindByCredentialID(ctx context.Context, credentialID []byte) (*models.Credential, error) {
+	var credential models.Credential
+	if err := d.collection.FindOne(ctx, bson.D{{Key: "credentialId", Value: credentialID}}).Decode(&credential); err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+func (d *credentialDao) UpdateSignCount(ctx context.Context, id primitive.ObjectID, signCount uint32) error {
+	_, err := d.collection.UpdateOne(
+		ctx,
+		bson.D{{Key: "_id", Value: id}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "signCount", Value: signCount}}}},
+	)
+	return err
+}
+
+func newCredentialDao(db *mongo.Database) *credentialDao {
+	return &credentialDao{collection: db.Collection(credentialsCollection)}
+}
@@ -0,0 +1,68 @@
+package dao
+
+import (
+	"github.com/knuls/horus/validator"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Factory lazily builds and hands out the DAOs for a single Mongo database,
+// so handlers depend on one factory instead of wiring up each collection.
+type Factory struct {
+	db              *mongo.Database
+	validate        *validator.Validator
+	userDao         UserDao
+	tokenDao        TokenDao
+	credentialDao   CredentialDao
+	challengeDao    ChallengeDao
+	organizationDao OrganizationDao
+	verificationDao VerificationDao
+}
+
+func (f *Factory) GetUserDao() UserDao {
+	if f.userDao == nil {
+		f.userDao = newUserDao(f.db)
+	}
+	return f.userDao
+}
+
+func (f *Factory) GetTokenDao() TokenDao {
+	if f.tokenDao == nil {
+		f.tokenDao = newTokenDao(f.db)
+	}
+	return f.tokenDao
+}
+
+func (f *Factory) GetCredentialDao() CredentialDao {
+	if f.credentialDao == nil {
+		f.credentialDao = newCredentialDao(f.db)
+	}
+	return f.credentialDao
+}
+
+func (f *Factory) GetChallengeDao() ChallengeDao {
+	if f.challengeDao == nil {
+		f.challengeDao = newChallengeDao(f.db)
+	}
+	return f.challengeDao
+}
+
+func (f *Factory) GetOrganizationDao() OrganizationDao {
+	if f.organizationDao == nil {
+		f.organizationDao = newOrganizationDao(f.db)
+	}
+	return f.organizationDao
+}
+
+func (f *Factory) GetVerificationDao() VerificationDao {
+	if f.verificationDao == nil {
+		f.verificationDao = newVerificationDao(f.db)
+	}
+	return f.verificationDao
+}
+
+func NewFactory(db *mongo.Database, validate *validator.Validator) *Factory {
+	return &Factory{
+		db:       db,
+		validate: validate,
+	}
+}
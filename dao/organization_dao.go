@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/knuls/bennu/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const organizationsCollection = "organizations"
+
+type OrganizationDao interface {
+	Create(ctx context.Context, org *models.Organization) (string, error)
+	FindById(ctx context.Context, id primitive.ObjectID) (*models.Organization, error)
+	FindByMember(ctx context.Context, userID primitive.ObjectID) ([]*models.Organization, error)
+	Invite(ctx context.Context, id primitive.ObjectID, invite models.Invite) error
+	AcceptInvite(ctx context.Context, id primitive.ObjectID, token string, member models.Member) error
+	AddMember(ctx context.Context, id primitive.ObjectID, member models.Member) error
+}
+
+type organizationDao struct {
+	collection *mongo.Collection
+}
+
+func (d *organizationDao) Create(ctx context.Context, org *models.Organization) (string, error) {
+	res, err := d.collection.InsertOne(ctx, org)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (d *organizationDao) FindById(ctx context.Context, id primitive.ObjectID) (*models.Organization, error) {
+	var org models.Organization
+	if err := d.collection.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (d *organizationDao) FindByMember(ctx context.Context, userID primitive.ObjectID) ([]*models.Organization, error) {
+	cursor, err := d.collection.Find(ctx, bson.D{{Key: "members.userId", Value: userID}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	orgs := []*models.Organization{}
+	if err := cursor.All(ctx, &orgs); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+func (d *organizationDao) Invite(ctx context.Context, id primitive.ObjectID, invite models.Invite) error {
+	_, err := d.collection.UpdateOne(
+		ctx,
+		bson.D{{Key: "_id", Value: id}},
+		bson.D{{Key: "$push", Value: bson.D{{Key: "invites", Value: invite}}}},
+	)
+	return err
+}
+
+// AcceptInvite atomically consumes a still-valid invite token and adds the
+// accepting user as a member in the same update, so a token can't be
+// replayed into duplicate membership.
+func (d *organizationDao) AcceptInvite(ctx context.Context, id primitive.ObjectID, token string, member models.Member) error {
+	where := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "invites", Value: bson.D{{Key: "$elemMatch", Value: bson.D{
+			{Key: "token", Value: token},
+			{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+		}}}},
+	}
+	update := bson.D{
+		{Key: "$pull", Value: bson.D{{Key: "invites", Value: bson.D{{Key: "token", Value: token}}}}},
+		{Key: "$push", Value: bson.D{{Key: "members", Value: member}}},
+	}
+	res, err := d.collection.UpdateOne(ctx, where, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// AddMember appends member directly, bypassing the invite flow — used when
+// an admin provisions a user for the organization rather than the user
+// accepting an invite themselves.
+func (d *organizationDao) AddMember(ctx context.Context, id primitive.ObjectID, member models.Member) error {
+	_, err := d.collection.UpdateOne(
+		ctx,
+		bson.D{{Key: "_id", Value: id}},
+		bson.D{{Key: "$push", Value: bson.D{{Key: "members", Value: member}}}},
+	)
+	return err
+}
+
+func newOrganizationDao(db *mongo.Database) *organizationDao {
+	return &organizationDao{collection: db.Collection(organizationsCollection)}
+}
@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/knuls/bennu/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const tokensCollection = "refreshTokens"
+
+type TokenDao interface {
+	Create(ctx context.Context, token *models.RefreshToken) (string, error)
+	FindByHash(ctx context.Context, hash string) (*models.RefreshToken, error)
+	Revoke(ctx context.Context, id primitive.ObjectID) error
+	RevokeFamily(ctx context.Context, familyID primitive.ObjectID) error
+	RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type tokenDao struct {
+	collection *mongo.Collection
+}
+
+func (d *tokenDao) Create(ctx context.Context, token *models.RefreshToken) (string, error) {
+	res, err := d.collection.InsertOne(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (d *tokenDao) FindByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	where := Where{{Key: "tokenHash", Value: hash}}
+	if err := d.collection.FindOne(ctx, bson.D(where)).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Revoke atomically marks id revoked, but only if it hasn't already been
+// revoked — the guard is what makes it safe for two concurrent requests to
+// race on the same refresh token: at most one can win. Returns
+// mongo.ErrNoDocuments if the token was already revoked.
+func (d *tokenDao) Revoke(ctx context.Context, id primitive.ObjectID) error {
+	res, err := d.collection.UpdateOne(
+		ctx,
+		bson.D{{Key: "_id", Value: id}, {Key: "revokedAt", Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "revokedAt", Value: time.Now()}}}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (d *tokenDao) RevokeFamily(ctx context.Context, familyID primitive.ObjectID) error {
+	_, err := d.collection.UpdateMany(
+		ctx,
+		bson.D{{Key: "familyId", Value: familyID}, {Key: "revokedAt", Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "revokedAt", Value: time.Now()}}}},
+	)
+	return err
+}
+
+// RevokeAllForUser revokes every outstanding refresh token belonging to
+// userID, across every family — used when a password reset should log the
+// account out of every device.
+func (d *tokenDao) RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := d.collection.UpdateMany(
+		ctx,
+		bson.D{{Key: "userId", Value: userID}, {Key: "revokedAt", Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "revokedAt", Value: time.Now()}}}},
+	)
+	return err
+}
+
+func newTokenDao(db *mongo.Database) *tokenDao {
+	return &tokenDao{collection: db.Collection(tokensCollection)}
+}
@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/knuls/bennu/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testDatabase connects to a local MongoDB and skips the test if one isn't
+// reachable — this repo has no mock Mongo deployment, so Revoke's guarded
+// update can only be exercised against a real server.
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://localhost:27017"))
+	if err != nil {
+		t.Skipf("mongo unreachable: %v", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Skipf("mongo unreachable: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Disconnect(context.Background())
+	})
+	return client.Database("bennu_test")
+}
+
+// TestTokenDaoRevokeRejectsReuse covers the race TokenRefresh relies on:
+// once a refresh token is revoked, a second Revoke for the same ID must
+// report mongo.ErrNoDocuments rather than silently succeeding, so a
+// concurrent replay is detected instead of minting a second token pair.
+func TestTokenDaoRevokeRejectsReuse(t *testing.T) {
+	db := testDatabase(t)
+	d := newTokenDao(db)
+	defer db.Collection(tokensCollection).Drop(context.Background())
+
+	token := &models.RefreshToken{
+		UserID:    primitive.NewObjectID(),
+		FamilyID:  primitive.NewObjectID(),
+		TokenHash: "hash",
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	idHex, err := d.Create(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		t.Fatalf("ObjectIDFromHex: %v", err)
+	}
+
+	if err := d.Revoke(context.Background(), id); err != nil {
+		t.Fatalf("first Revoke: %v", err)
+	}
+	if err := d.Revoke(context.Background(), id); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Fatalf("second Revoke: expected mongo.ErrNoDocuments, got %v", err)
+	}
+}
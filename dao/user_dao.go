@@ -0,0 +1,93 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/knuls/bennu/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const usersCollection = "users"
+
+type UserDao interface {
+	Find(ctx context.Context, where Where) ([]*models.User, error)
+	FindOne(ctx context.Context, where Where) (*models.User, error)
+	FindById(ctx context.Context, id string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) (string, error)
+	Update(ctx context.Context, id string, update bson.M) error
+	Delete(ctx context.Context, id string) error
+	SetRoles(ctx context.Context, id string, admin bool, roles []string) error
+}
+
+type userDao struct {
+	collection *mongo.Collection
+}
+
+func (d *userDao) Find(ctx context.Context, where Where) ([]*models.User, error) {
+	cursor, err := d.collection.Find(ctx, bson.D(where))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	users := []*models.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (d *userDao) FindOne(ctx context.Context, where Where) (*models.User, error) {
+	var user models.User
+	if err := d.collection.FindOne(ctx, bson.D(where)).Decode(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (d *userDao) FindById(ctx context.Context, id string) (*models.User, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return d.FindOne(ctx, Where{{Key: "_id", Value: oid}})
+}
+
+func (d *userDao) Create(ctx context.Context, user *models.User) (string, error) {
+	res, err := d.collection.InsertOne(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (d *userDao) Update(ctx context.Context, id string, update bson.M) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = d.collection.UpdateOne(
+		ctx,
+		bson.D{{Key: "_id", Value: oid}},
+		bson.D{{Key: "$set", Value: update}},
+	)
+	return err
+}
+
+func (d *userDao) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = d.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: oid}})
+	return err
+}
+
+func (d *userDao) SetRoles(ctx context.Context, id string, admin bool, roles []string) error {
+	return d.Update(ctx, id, bson.M{"admin": admin, "roles": roles})
+}
+
+func newUserDao(db *mongo.Database) *userDao {
+	return &userDao{collection: db.Collection(usersCollection)}
+}
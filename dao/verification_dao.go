@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/knuls/bennu/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const verificationTokensCollection = "verificationTokens"
+
+const verificationTokenTTL = 24 * time.Hour
+
+type VerificationDao interface {
+	Create(ctx context.Context, token *models.VerificationToken) (string, error)
+	FindByHash(ctx context.Context, hash, purpose string) (*models.VerificationToken, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	DeleteByUser(ctx context.Context, userID primitive.ObjectID, purpose string) error
+}
+
+type verificationDao struct {
+	collection *mongo.Collection
+}
+
+func (d *verificationDao) Create(ctx context.Context, token *models.VerificationToken) (string, error) {
+	token.CreatedAt = time.Now()
+	token.ExpiresAt = token.CreatedAt.Add(verificationTokenTTL)
+	res, err := d.collection.InsertOne(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (d *verificationDao) FindByHash(ctx context.Context, hash, purpose string) (*models.VerificationToken, error) {
+	var token models.VerificationToken
+	where := Where{
+		{Key: "tokenHash", Value: hash},
+		{Key: "purpose", Value: purpose},
+		{Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: time.Now()}}},
+	}
+	if err := d.collection.FindOne(ctx, bson.D(where)).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (d *verificationDao) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := d.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	return err
+}
+
+func (d *verificationDao) DeleteByUser(ctx context.Context, userID primitive.ObjectID, purpose string) error {
+	_, err := d.collection.DeleteMany(ctx, bson.D{{Key: "userId", Value: userID}, {Key: "purpose", Value: purpose}})
+	return err
+}
+
+func newVerificationDao(db *mongo.Database) *verificationDao {
+	return &verificationDao{collection: db.Collection(verificationTokensCollection)}
+}
@@ -0,0 +1,7 @@
+package dao
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// Where is a loosely-typed Mongo filter document, built up as a bson.D
+// literal at the call site, e.g. dao.Where{{Key: "email", Value: email}}.
+type Where bson.D
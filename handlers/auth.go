@@ -4,27 +4,66 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/knuls/bennu/auth"
 	"github.com/knuls/bennu/dao"
+	"github.com/knuls/bennu/mail"
+	bennumw "github.com/knuls/bennu/middlewares"
 	"github.com/knuls/bennu/models"
-	"github.com/knuls/horus/logger"
+	"github.com/knuls/bennu/service"
 	"github.com/knuls/horus/res"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const refreshTokenCookieName = "refresh_token"
+
+// challengeIDHeader correlates a WebAuthn ceremony's finish request with the
+// server-side session created by its begin request.
+const challengeIDHeader = "X-Challenge-Id"
+
 type loginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
+type registerRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type webauthnLoginBeginRequest struct {
+	Email string `json:"email"`
+}
+
+type resetPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type verifyTokenRequest struct {
+	Token string `json:"token"`
+}
+
+type verifyResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
 type AuthHandler struct {
-	logger     *logger.Logger
+	logger     *slog.Logger
 	daoFactory *dao.Factory
+	signer     *auth.Signer
+	webauthn   *webauthn.WebAuthn
+	mailer     mail.Mailer
 }
 
 func (h *AuthHandler) Routes() *chi.Mux {
@@ -41,6 +80,17 @@ func (h *AuthHandler) Routes() *chi.Mux {
 	mux.Route("/token", func(mux chi.Router) {
 		mux.Post("/refresh", h.TokenRefresh) // POST /auth/token/refresh
 	})
+	mux.Route("/webauthn", func(mux chi.Router) {
+		mux.Route("/register", func(mux chi.Router) {
+			mux.Use(bennumw.RequireAuth(h.signer))
+			mux.Post("/begin", h.WebAuthnRegisterBegin)   // POST /auth/webauthn/register/begin
+			mux.Post("/finish", h.WebAuthnRegisterFinish) // POST /auth/webauthn/register/finish
+		})
+		mux.Route("/login", func(mux chi.Router) {
+			mux.Post("/begin", h.WebAuthnLoginBegin)   // POST /auth/webauthn/login/begin
+			mux.Post("/finish", h.WebAuthnLoginFinish) // POST /auth/webauthn/login/finish
+		})
+	})
 	return mux
 }
 
@@ -75,20 +125,37 @@ func (h *AuthHandler) Login(rw http.ResponseWriter, r *http.Request) {
 	}
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password))
 	if err != nil {
+		bennumw.LoggerFromContext(r.Context()).WarnContext(r.Context(), "login failed", slog.String("email", payload.Email))
 		render.Render(rw, r, res.ErrNotFound(errors.New("invalid username or password")))
 		return
 	}
 
-	// TODO: create access & refresh tokens
-	// TODO: set access token in resp & refresh token in cookie
+	credentials, err := h.daoFactory.GetCredentialDao().FindByUserID(r.Context(), user.ID)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	if len(credentials) > 0 {
+		// Password alone is not enough: the client must now complete a
+		// WebAuthn assertion via /auth/webauthn/login/begin + /finish.
+		render.Status(r, http.StatusOK)
+		render.Respond(rw, r, &res.JSON{"mfaRequired": true})
+		return
+	}
+
+	accessToken, err := h.issueTokenPair(rw, r, user.ID, primitive.NewObjectID())
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
 
 	render.Status(r, http.StatusOK)
-	render.Respond(rw, r, &res.JSON{"token": "token"})
+	render.Respond(rw, r, &res.JSON{"token": accessToken})
 }
 
 func (h *AuthHandler) Register(rw http.ResponseWriter, r *http.Request) {
-	var user *models.User
-	err := json.NewDecoder(r.Body).Decode(&user)
+	var payload registerRequest
+	err := json.NewDecoder(r.Body).Decode(&payload)
 	defer r.Body.Close()
 	if err == io.EOF {
 		render.Render(rw, r, res.ErrDecode(err))
@@ -98,51 +165,460 @@ func (h *AuthHandler) Register(rw http.ResponseWriter, r *http.Request) {
 		render.Render(rw, r, res.ErrDecode(err))
 		return
 	}
-	now := time.Now()
-	user.Verified = false
-	user.CreatedAt = now
-	user.UpdatedAt = now
-	bytes, err := bcrypt.GenerateFromPassword([]byte(user.Password), 14)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(payload.Password), 14)
 	if err != nil {
 		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
 		return
 	}
-	user.Password = string(bytes)
+	now := time.Now()
+	user := &models.User{
+		Email:     payload.Email,
+		Password:  string(bytes),
+		Verified:  false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
 	id, err := h.daoFactory.GetUserDao().Create(r.Context(), user)
 	if err != nil {
 		render.Render(rw, r, res.ErrBadRequest(err))
 		return
 	}
-
-	// TODO: create token & send verify email with token
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err == nil {
+		if err := h.sendVerificationEmail(r, user.Email, oid); err != nil {
+			bennumw.LoggerFromContext(r.Context()).ErrorContext(r.Context(), "send verification email failed", slog.Any("err", err), slog.String("userId", id))
+		}
+	}
 
 	render.Status(r, http.StatusCreated)
 	render.Respond(rw, r, &res.JSON{"id": id})
 }
 
+// ResetPassword always responds 202, whether or not the email matches an
+// account, so the endpoint can't be used to enumerate registered addresses.
 func (h *AuthHandler) ResetPassword(rw http.ResponseWriter, r *http.Request) {
-	//
+	var payload *resetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	defer r.Body.Close()
+
+	user, err := h.daoFactory.GetUserDao().FindOne(r.Context(), dao.Where{{Key: "email", Value: payload.Email}})
+	if err == nil {
+		h.daoFactory.GetVerificationDao().DeleteByUser(r.Context(), user.ID, models.VerificationPurposeResetPassword)
+		if err := h.sendResetPasswordEmail(r, user.Email, user.ID); err != nil {
+			bennumw.LoggerFromContext(r.Context()).ErrorContext(r.Context(), "send reset password email failed", slog.Any("err", err), slog.String("userId", user.ID.Hex()))
+		}
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.Respond(rw, r, nil)
 }
 
+// VerifyEmail consumes a verify_email token and marks the owning user as
+// verified.
 func (h *AuthHandler) VerifyEmail(rw http.ResponseWriter, r *http.Request) {
-	//
+	var payload *verifyTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	defer r.Body.Close()
+
+	hash := auth.HashVerificationToken(payload.Token)
+	token, err := h.daoFactory.GetVerificationDao().FindByHash(r.Context(), hash, models.VerificationPurposeEmail)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(errors.New("invalid or expired token")))
+		return
+	}
+	if err := h.daoFactory.GetUserDao().Update(r.Context(), token.UserID.Hex(), bson.M{"verified": true}); err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	h.daoFactory.GetVerificationDao().Delete(r.Context(), token.ID)
+
+	render.Status(r, http.StatusNoContent)
+	render.Respond(rw, r, nil)
 }
 
+// VerifyResetPassword consumes a reset_password token, rehashes the new
+// password, and revokes every outstanding refresh token for the user so a
+// stolen session can't survive the reset.
 func (h *AuthHandler) VerifyResetPassword(rw http.ResponseWriter, r *http.Request) {
-	//
+	var payload *verifyResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	defer r.Body.Close()
+
+	hash := auth.HashVerificationToken(payload.Token)
+	token, err := h.daoFactory.GetVerificationDao().FindByHash(r.Context(), hash, models.VerificationPurposeResetPassword)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(errors.New("invalid or expired token")))
+		return
+	}
+	bytes, err := bcrypt.GenerateFromPassword([]byte(payload.Password), 14)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	if err := h.daoFactory.GetUserDao().Update(r.Context(), token.UserID.Hex(), bson.M{"password": string(bytes)}); err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	h.daoFactory.GetVerificationDao().Delete(r.Context(), token.ID)
+	if err := h.daoFactory.GetTokenDao().RevokeAllForUser(r.Context(), token.UserID); err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.Respond(rw, r, nil)
 }
 
+// TokenRefresh validates the refresh cookie, rotates it, and mints a new
+// access/refresh pair. Presenting an already-revoked token in a family is
+// treated as theft and revokes every token in that family.
 func (h *AuthHandler) TokenRefresh(rw http.ResponseWriter, r *http.Request) {
-	//
+	cookie, err := r.Cookie(refreshTokenCookieName)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusUnauthorized))
+		return
+	}
+	tokenDao := h.daoFactory.GetTokenDao()
+	hash := auth.HashRefreshToken(cookie.Value)
+	existing, err := tokenDao.FindByHash(r.Context(), hash)
+	if err != nil {
+		render.Render(rw, r, res.Err(errors.New("invalid refresh token"), http.StatusUnauthorized))
+		return
+	}
+	if existing.RevokedAt != nil {
+		if err := tokenDao.RevokeFamily(r.Context(), existing.FamilyID); err != nil {
+			render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+			return
+		}
+		render.Render(rw, r, res.Err(errors.New("refresh token reuse detected"), http.StatusUnauthorized))
+		return
+	}
+	if time.Now().After(existing.ExpiresAt) {
+		render.Render(rw, r, res.Err(errors.New("refresh token expired"), http.StatusUnauthorized))
+		return
+	}
+	if err := tokenDao.Revoke(r.Context(), existing.ID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// Lost the race to revoke this token first — someone else
+			// already consumed it, so treat this presentation as reuse.
+			if err := tokenDao.RevokeFamily(r.Context(), existing.FamilyID); err != nil {
+				render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+				return
+			}
+			render.Render(rw, r, res.Err(errors.New("refresh token reuse detected"), http.StatusUnauthorized))
+			return
+		}
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+
+	accessToken, err := h.issueTokenPair(rw, r, existing.UserID, existing.FamilyID)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.Respond(rw, r, &res.JSON{"token": accessToken})
 }
 
 func (h *AuthHandler) Logout(rw http.ResponseWriter, r *http.Request) {
-	//
+	cookie, err := r.Cookie(refreshTokenCookieName)
+	if err == nil {
+		if existing, err := h.daoFactory.GetTokenDao().FindByHash(r.Context(), auth.HashRefreshToken(cookie.Value)); err == nil {
+			h.daoFactory.GetTokenDao().RevokeFamily(r.Context(), existing.FamilyID)
+		}
+	}
+	http.SetCookie(rw, &http.Cookie{
+		Name:     refreshTokenCookieName,
+		Value:    "",
+		Path:     "/auth",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	render.Status(r, http.StatusNoContent)
+	render.Respond(rw, r, nil)
+}
+
+// issueTokenPair mints an access token plus a new refresh token in familyID,
+// persists the refresh token's hash, and sets it as the response cookie.
+func (h *AuthHandler) issueTokenPair(rw http.ResponseWriter, r *http.Request, userID, familyID primitive.ObjectID) (string, error) {
+	accessToken, err := h.signer.IssueAccessToken(userID.Hex())
+	if err != nil {
+		return "", err
+	}
+	plain, hash, err := h.signer.NewRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	refreshToken := &models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hash,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(h.signer.RefreshTTL()),
+	}
+	if _, err := h.daoFactory.GetTokenDao().Create(r.Context(), refreshToken); err != nil {
+		return "", err
+	}
+	http.SetCookie(rw, &http.Cookie{
+		Name:     refreshTokenCookieName,
+		Value:    plain,
+		Path:     "/auth",
+		Expires:  refreshToken.ExpiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return accessToken, nil
+}
+
+// WebAuthnRegisterBegin starts passkey registration for the authenticated
+// user and returns creation options alongside a challenge ID the client
+// must echo back (as X-Challenge-Id) on /register/finish.
+func (h *AuthHandler) WebAuthnRegisterBegin(rw http.ResponseWriter, r *http.Request) {
+	claims, ok := bennumw.ClaimsFromContext(r.Context())
+	if !ok {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	user, credentials, err := h.loadUserAndCredentials(r, claims.Subject)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	options, sessionData, err := h.webauthn.BeginRegistration(auth.NewWebAuthnUser(user, credentials))
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	challengeID, err := h.storeChallenge(r, user.ID.Hex(), models.ChallengePurposeRegister, sessionData)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(rw, r, &res.JSON{"challengeId": challengeID, "publicKey": options.Response})
+}
+
+func (h *AuthHandler) WebAuthnRegisterFinish(rw http.ResponseWriter, r *http.Request) {
+	claims, ok := bennumw.ClaimsFromContext(r.Context())
+	if !ok {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	challenge, sessionData, err := h.loadChallenge(r, r.Header.Get(challengeIDHeader), models.ChallengePurposeRegister)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	user, credentials, err := h.loadUserAndCredentials(r, claims.Subject)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBody(r.Body)
+	if err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	credential, err := h.webauthn.CreateCredential(auth.NewWebAuthnUser(user, credentials), *sessionData, parsed)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	model := auth.ToModelCredential(credential)
+	model.UserID = user.ID
+	model.CreatedAt = time.Now()
+	if _, err := h.daoFactory.GetCredentialDao().Create(r.Context(), model); err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	h.daoFactory.GetChallengeDao().Delete(r.Context(), challenge.ID)
+	render.Status(r, http.StatusCreated)
+	render.Respond(rw, r, &res.JSON{"verified": true})
+}
+
+func (h *AuthHandler) WebAuthnLoginBegin(rw http.ResponseWriter, r *http.Request) {
+	var payload *webauthnLoginBeginRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	user, err := h.daoFactory.GetUserDao().FindOne(r.Context(), dao.Where{{Key: "email", Value: payload.Email}})
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	credentials, err := h.daoFactory.GetCredentialDao().FindByUserID(r.Context(), user.ID)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	if len(credentials) == 0 {
+		render.Render(rw, r, res.ErrBadRequest(errors.New("no passkeys registered")))
+		return
+	}
+	options, sessionData, err := h.webauthn.BeginLogin(auth.NewWebAuthnUser(user, credentials))
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	challengeID, err := h.storeChallenge(r, user.ID.Hex(), models.ChallengePurposeLogin, sessionData)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(rw, r, &res.JSON{"challengeId": challengeID, "publicKey": options.Response})
+}
+
+func (h *AuthHandler) WebAuthnLoginFinish(rw http.ResponseWriter, r *http.Request) {
+	challenge, sessionData, err := h.loadChallenge(r, r.Header.Get(challengeIDHeader), models.ChallengePurposeLogin)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	user, credentials, err := h.loadUserAndCredentials(r, challenge.UserID)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBody(r.Body)
+	if err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	credential, err := h.webauthn.ValidateLogin(auth.NewWebAuthnUser(user, credentials), *sessionData, parsed)
+	if err != nil {
+		render.Render(rw, r, res.ErrNotFound(errors.New("invalid assertion")))
+		return
+	}
+	for _, c := range credentials {
+		if string(c.CredentialID) == string(credential.ID) {
+			h.daoFactory.GetCredentialDao().UpdateSignCount(r.Context(), c.ID, credential.Authenticator.SignCount)
+			break
+		}
+	}
+	h.daoFactory.GetChallengeDao().Delete(r.Context(), challenge.ID)
+
+	accessToken, err := h.issueTokenPair(rw, r, user.ID, primitive.NewObjectID())
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(rw, r, &res.JSON{"token": accessToken})
+}
+
+func (h *AuthHandler) loadUserAndCredentials(r *http.Request, userIDHex string) (*models.User, []*models.Credential, error) {
+	oid, err := primitive.ObjectIDFromHex(userIDHex)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := h.daoFactory.GetUserDao().FindOne(r.Context(), dao.Where{{Key: "_id", Value: oid}})
+	if err != nil {
+		return nil, nil, err
+	}
+	credentials, err := h.daoFactory.GetCredentialDao().FindByUserID(r.Context(), user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, credentials, nil
+}
+
+func (h *AuthHandler) storeChallenge(r *http.Request, userID, purpose string, sessionData *webauthn.SessionData) (string, error) {
+	id, err := auth.NewOpaqueID()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(sessionData)
+	if err != nil {
+		return "", err
+	}
+	challenge := &models.Challenge{ID: id, UserID: userID, Purpose: purpose, SessionData: data}
+	if err := h.daoFactory.GetChallengeDao().Create(r.Context(), challenge); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (h *AuthHandler) loadChallenge(r *http.Request, id, purpose string) (*models.Challenge, *webauthn.SessionData, error) {
+	if id == "" {
+		return nil, nil, errors.New("missing " + challengeIDHeader + " header")
+	}
+	challenge, err := h.daoFactory.GetChallengeDao().FindByID(r.Context(), id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge.Purpose != purpose {
+		return nil, nil, errors.New("challenge purpose mismatch")
+	}
+	var sessionData webauthn.SessionData
+	if err := json.Unmarshal(challenge.SessionData, &sessionData); err != nil {
+		return nil, nil, err
+	}
+	return challenge, &sessionData, nil
+}
+
+// sendVerificationEmail issues a verify_email token for userID and emails
+// it to address.
+func (h *AuthHandler) sendVerificationEmail(r *http.Request, address string, userID primitive.ObjectID) error {
+	plain, err := h.issueVerificationToken(r, userID, models.VerificationPurposeEmail)
+	if err != nil {
+		return err
+	}
+	return h.mailer.Send(r.Context(), mail.Message{
+		To:      address,
+		Subject: "Verify your email",
+		Body:    "Use this code to verify your email: " + plain,
+	})
+}
+
+// sendResetPasswordEmail issues a reset_password token for userID and emails
+// it to address.
+func (h *AuthHandler) sendResetPasswordEmail(r *http.Request, address string, userID primitive.ObjectID) error {
+	plain, err := h.issueVerificationToken(r, userID, models.VerificationPurposeResetPassword)
+	if err != nil {
+		return err
+	}
+	return h.mailer.Send(r.Context(), mail.Message{
+		To:      address,
+		Subject: "Reset your password",
+		Body:    "Use this code to reset your password: " + plain,
+	})
+}
+
+func (h *AuthHandler) issueVerificationToken(r *http.Request, userID primitive.ObjectID, purpose string) (string, error) {
+	plain, hash, err := auth.NewVerificationToken()
+	if err != nil {
+		return "", err
+	}
+	token := &models.VerificationToken{UserID: userID, TokenHash: hash, Purpose: purpose}
+	if _, err := h.daoFactory.GetVerificationDao().Create(r.Context(), token); err != nil {
+		return "", err
+	}
+	return plain, nil
 }
 
-func NewAuthHandler(logger *logger.Logger, factory *dao.Factory) *AuthHandler {
+func NewAuthHandler(p *service.Provider) *AuthHandler {
 	return &AuthHandler{
-		logger:     logger,
-		daoFactory: factory,
+		logger:     p.Logger,
+		daoFactory: p.Factory,
+		signer:     p.Signer,
+		webauthn:   p.WebAuthn,
+		mailer:     p.Mailer,
 	}
 }
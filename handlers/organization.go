@@ -1,32 +1,203 @@
 package handlers
 
 import (
-	"github.com/bachehah/horus/logger"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
 	"github.com/go-chi/chi/v5"
-	"github.com/go-playground/validator"
-	"go.mongodb.org/mongo-driver/mongo"
+	"github.com/go-chi/render"
+	"github.com/knuls/bennu/auth"
+	"github.com/knuls/bennu/dao"
+	bennumw "github.com/knuls/bennu/middlewares"
+	"github.com/knuls/bennu/models"
+	"github.com/knuls/bennu/service"
+	"github.com/knuls/horus/middlewares"
+	"github.com/knuls/horus/res"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+type createOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+type inviteRequest struct {
+	Email string `json:"email"`
+}
+
+type acceptInviteRequest struct {
+	Token string `json:"token"`
+}
+
 type OrganizationHandler struct {
-	Logger   *logger.Logger
-	Validate *validator.Validate
-	Client   *mongo.Client
+	logger     *slog.Logger
+	daoFactory *dao.Factory
+	signer     *auth.Signer
 }
 
 func (h *OrganizationHandler) Routes() *chi.Mux {
 	mux := chi.NewRouter()
-	mux.Get("/", nil)  // GET /organization
-	mux.Post("/", nil) // POST /organization
-	mux.Route("/{id}", func(mux chi.Router) {
-		mux.Get("/", nil) // GET /organization/:id
+	mux.Use(bennumw.RequireAuth(h.signer))
+	mux.Get("/", h.Find)    // GET /organization
+	mux.Post("/", h.Create) // POST /organization
+	mux.Route("/{orgID}", func(mux chi.Router) {
+		mux.Use(middlewares.ValidateObjectID("orgID"))
+		// Accepting an invite is how a non-member joins, so it is not
+		// behind OrganizationCtx's membership check.
+		mux.Post("/accept", h.AcceptInvite) // POST /organization/:orgID/accept
+		mux.Group(func(mux chi.Router) {
+			mux.Use(bennumw.OrganizationCtx(h.daoFactory))
+			mux.Get("/", h.FindById)      // GET /organization/:orgID
+			mux.Post("/invite", h.Invite) // POST /organization/:orgID/invite
+		})
 	})
 	return mux
 }
 
-func NewOrganizationHandler(logger *logger.Logger, validate *validator.Validate, client *mongo.Client) *UserHandler {
-	return &UserHandler{
-		Logger:   logger,
-		Validate: validate,
-		Client:   client,
+func (h *OrganizationHandler) Find(rw http.ResponseWriter, r *http.Request) {
+	claims, ok := bennumw.ClaimsFromContext(r.Context())
+	if !ok {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	userID, err := primitive.ObjectIDFromHex(claims.Subject)
+	if err != nil {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	orgs, err := h.daoFactory.GetOrganizationDao().FindByMember(r.Context(), userID)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	renders := []render.Renderer{}
+	for _, org := range orgs {
+		renders = append(renders, org)
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(rw, r, &res.JSON{"organizations": renders})
+}
+
+func (h *OrganizationHandler) Create(rw http.ResponseWriter, r *http.Request) {
+	claims, ok := bennumw.ClaimsFromContext(r.Context())
+	if !ok {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	userID, err := primitive.ObjectIDFromHex(claims.Subject)
+	if err != nil {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	var payload *createOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	defer r.Body.Close()
+	now := time.Now()
+	org := &models.Organization{
+		Name:    payload.Name,
+		OwnerID: userID,
+		Members: []models.Member{
+			{UserID: userID, Role: models.OrganizationRoleOwner, JoinedAt: now},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	id, err := h.daoFactory.GetOrganizationDao().Create(r.Context(), org)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(rw, r, &res.JSON{"id": id})
+}
+
+func (h *OrganizationHandler) FindById(rw http.ResponseWriter, r *http.Request) {
+	orgID, _ := bennumw.OrganizationIDFromContext(r.Context())
+	org, err := h.daoFactory.GetOrganizationDao().FindById(r.Context(), orgID)
+	if err != nil {
+		render.Render(rw, r, res.ErrNotFound(err))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(rw, r, &res.JSON{"organization": org})
+}
+
+// Invite appends a single-use, time-limited invite for email; acceptance
+// happens out-of-band once the invitee authenticates and calls AcceptInvite.
+func (h *OrganizationHandler) Invite(rw http.ResponseWriter, r *http.Request) {
+	orgID, _ := bennumw.OrganizationIDFromContext(r.Context())
+	claims, ok := bennumw.ClaimsFromContext(r.Context())
+	if !ok {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	var payload *inviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	defer r.Body.Close()
+	token, err := auth.NewOpaqueID()
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	now := time.Now()
+	invite := models.Invite{
+		Email:     payload.Email,
+		Token:     token,
+		InvitedBy: claims.Subject,
+		CreatedAt: now,
+		ExpiresAt: now.Add(7 * 24 * time.Hour),
+	}
+	if err := h.daoFactory.GetOrganizationDao().Invite(r.Context(), orgID, invite); err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(rw, r, &res.JSON{"token": token})
+}
+
+func (h *OrganizationHandler) AcceptInvite(rw http.ResponseWriter, r *http.Request) {
+	orgID, err := primitive.ObjectIDFromHex(chi.URLParam(r, "orgID"))
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	claims, ok := bennumw.ClaimsFromContext(r.Context())
+	if !ok {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	userID, err := primitive.ObjectIDFromHex(claims.Subject)
+	if err != nil {
+		render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+		return
+	}
+	var payload *acceptInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	defer r.Body.Close()
+	member := models.Member{UserID: userID, Role: models.OrganizationRoleMember, JoinedAt: time.Now()}
+	if err := h.daoFactory.GetOrganizationDao().AcceptInvite(r.Context(), orgID, payload.Token, member); err != nil {
+		render.Render(rw, r, res.ErrBadRequest(errors.New("invalid or expired invite")))
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(rw, r, nil)
+}
+
+func NewOrganizationHandler(p *service.Provider) *OrganizationHandler {
+	return &OrganizationHandler{
+		logger:     p.Logger,
+		daoFactory: p.Factory,
+		signer:     p.Signer,
 	}
 }
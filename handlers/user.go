@@ -2,37 +2,78 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/knuls/bennu/auth"
 	"github.com/knuls/bennu/dao"
-	"github.com/knuls/horus/logger"
+	bennumw "github.com/knuls/bennu/middlewares"
+	"github.com/knuls/bennu/models"
+	"github.com/knuls/bennu/service"
 	"github.com/knuls/horus/middlewares"
 	"github.com/knuls/horus/res"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type userIDCtxKey struct{}
 
+type updateUserRequest struct {
+	Email string   `json:"email"`
+	Admin *bool    `json:"admin"`
+	Roles []string `json:"roles"`
+}
+
 type UserHandler struct {
-	logger     *logger.Logger
+	logger     *slog.Logger
 	daoFactory *dao.Factory
+	signer     *auth.Signer
 }
 
 func (h *UserHandler) Routes() *chi.Mux {
 	mux := chi.NewRouter()
-	mux.Get("/", h.Find) // GET /user
+	mux.Use(bennumw.RequireAuth(h.signer))
+	mux.Use(bennumw.OrganizationCtx(h.daoFactory))
+	mux.Route("/", func(mux chi.Router) {
+		mux.Use(bennumw.RequireRole(h.daoFactory, "admin"))
+		mux.Get("/", h.Find)    // GET /user
+		mux.Post("/", h.Create) // POST /user
+	})
 	mux.Route("/{id}", func(mux chi.Router) {
 		mux.Use(middlewares.ValidateObjectID("id"))
 		mux.Use(UserCtx)
 		mux.Get("/", h.FindById) // GET /user/:id
+		mux.Group(func(mux chi.Router) {
+			mux.Use(bennumw.RequireRole(h.daoFactory, "admin"))
+			mux.Patch("/", h.Update)   // PATCH /user/:id
+			mux.Delete("/", h.Delete) // DELETE /user/:id
+		})
 	})
 	return mux
 }
 
+// Find lists users, scoped to the organization resolved by OrganizationCtx
+// so an admin in one org can never see another org's membership.
 func (h *UserHandler) Find(rw http.ResponseWriter, r *http.Request) {
-	users, err := h.daoFactory.GetUserDao().Find(r.Context(), dao.Where{})
+	orgID, _ := bennumw.OrganizationIDFromContext(r.Context())
+	org, err := h.daoFactory.GetOrganizationDao().FindById(r.Context(), orgID)
+	if err != nil {
+		render.Render(rw, r, res.ErrNotFound(err))
+		return
+	}
+	memberIDs := make([]primitive.ObjectID, len(org.Members))
+	for i, m := range org.Members {
+		memberIDs[i] = m.UserID
+	}
+	where := dao.Where{{Key: "_id", Value: bson.D{{Key: "$in", Value: memberIDs}}}}
+	users, err := h.daoFactory.GetUserDao().Find(r.Context(), where)
 	if err != nil {
 		render.Render(rw, r, res.ErrBadRequest(err))
 		return
@@ -55,6 +96,16 @@ func (h *UserHandler) FindById(rw http.ResponseWriter, r *http.Request) {
 		render.Render(rw, r, res.ErrBadRequest(err))
 		return
 	}
+	orgID, _ := bennumw.OrganizationIDFromContext(r.Context())
+	org, err := h.daoFactory.GetOrganizationDao().FindById(r.Context(), orgID)
+	if err != nil {
+		render.Render(rw, r, res.ErrNotFound(err))
+		return
+	}
+	if !org.HasMember(oid) {
+		render.Render(rw, r, res.ErrNotFound(errors.New("user not found in organization")))
+		return
+	}
 	user, err := h.daoFactory.GetUserDao().FindOne(r.Context(), dao.Where{{Key: "_id", Value: oid}})
 	if err != nil {
 		render.Render(rw, r, res.ErrBadRequest(err))
@@ -67,6 +118,121 @@ func (h *UserHandler) FindById(rw http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *UserHandler) Create(rw http.ResponseWriter, r *http.Request) {
+	var user *models.User
+	err := json.NewDecoder(r.Body).Decode(&user)
+	defer r.Body.Close()
+	if err == io.EOF || err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	bytes, err := bcrypt.GenerateFromPassword([]byte(user.Password), 14)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	user.Password = string(bytes)
+	id, err := h.daoFactory.GetUserDao().Create(r.Context(), user)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	orgID, _ := bennumw.OrganizationIDFromContext(r.Context())
+	member := models.Member{UserID: oid, Role: models.OrganizationRoleMember, JoinedAt: now}
+	if err := h.daoFactory.GetOrganizationDao().AddMember(r.Context(), orgID, member); err != nil {
+		render.Render(rw, r, res.Err(err, http.StatusInternalServerError))
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(rw, r, &res.JSON{"id": id})
+}
+
+func (h *UserHandler) Update(rw http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(userIDCtxKey{}).(string)
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	orgID, _ := bennumw.OrganizationIDFromContext(r.Context())
+	org, err := h.daoFactory.GetOrganizationDao().FindById(r.Context(), orgID)
+	if err != nil {
+		render.Render(rw, r, res.ErrNotFound(err))
+		return
+	}
+	if !org.HasMember(oid) {
+		render.Render(rw, r, res.ErrNotFound(errors.New("user not found in organization")))
+		return
+	}
+	var payload updateUserRequest
+	err = json.NewDecoder(r.Body).Decode(&payload)
+	defer r.Body.Close()
+	if err == io.EOF || err != nil {
+		render.Render(rw, r, res.ErrDecode(err))
+		return
+	}
+	if payload.Email != "" {
+		if err := h.daoFactory.GetUserDao().Update(r.Context(), id, bson.M{"email": payload.Email}); err != nil {
+			render.Render(rw, r, res.ErrBadRequest(err))
+			return
+		}
+	}
+	if payload.Admin != nil || payload.Roles != nil {
+		existing, err := h.daoFactory.GetUserDao().FindById(r.Context(), id)
+		if err != nil {
+			render.Render(rw, r, res.ErrBadRequest(err))
+			return
+		}
+		admin := existing.Admin
+		if payload.Admin != nil {
+			admin = *payload.Admin
+		}
+		roles := existing.Roles
+		if payload.Roles != nil {
+			roles = payload.Roles
+		}
+		if err := h.daoFactory.GetUserDao().SetRoles(r.Context(), id, admin, roles); err != nil {
+			render.Render(rw, r, res.ErrBadRequest(err))
+			return
+		}
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(rw, r, nil)
+}
+
+func (h *UserHandler) Delete(rw http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(userIDCtxKey{}).(string)
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	orgID, _ := bennumw.OrganizationIDFromContext(r.Context())
+	org, err := h.daoFactory.GetOrganizationDao().FindById(r.Context(), orgID)
+	if err != nil {
+		render.Render(rw, r, res.ErrNotFound(err))
+		return
+	}
+	if !org.HasMember(oid) {
+		render.Render(rw, r, res.ErrNotFound(errors.New("user not found in organization")))
+		return
+	}
+	if err := h.daoFactory.GetUserDao().Delete(r.Context(), id); err != nil {
+		render.Render(rw, r, res.ErrBadRequest(err))
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(rw, r, nil)
+}
+
 func UserCtx(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), userIDCtxKey{}, chi.URLParam(r, "id"))
@@ -74,9 +240,10 @@ func UserCtx(next http.Handler) http.Handler {
 	})
 }
 
-func NewUserHandler(logger *logger.Logger, factory *dao.Factory) *UserHandler {
+func NewUserHandler(p *service.Provider) *UserHandler {
 	return &UserHandler{
-		logger:     logger,
-		daoFactory: factory,
+		logger:     p.Logger,
+		daoFactory: p.Factory,
+		signer:     p.Signer,
 	}
 }
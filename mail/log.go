@@ -0,0 +1,25 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogMailer logs outgoing mail instead of sending it, for local development
+// and any environment without an SMTP relay configured.
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.InfoContext(ctx, "mail send",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body),
+	)
+	return nil
+}
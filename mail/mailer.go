@@ -0,0 +1,16 @@
+// Package mail dispatches outbound email behind a small interface so
+// handlers don't care whether delivery goes out over SMTP or is just logged.
+package mail
+
+import "context"
+
+// Message is a single outbound email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
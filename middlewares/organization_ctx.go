@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/knuls/bennu/auth"
+	"github.com/knuls/bennu/dao"
+	"github.com/knuls/horus/res"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgIDCtxKey struct{}
+
+const OrganizationIDHeader = "X-Organization-ID"
+
+// OrganizationCtx resolves the current organization from the
+// {orgID} URL param when the router has one (as under /organization/{orgID}),
+// falling back to the X-Organization-ID header, verifies the authenticated user is a member, and
+// injects the organization ID into the context for downstream DAO calls.
+func OrganizationCtx(factory *dao.Factory) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+				return
+			}
+			raw := chi.URLParam(r, "orgID")
+			if raw == "" {
+				raw = r.Header.Get(OrganizationIDHeader)
+			}
+			if raw == "" {
+				render.Render(rw, r, res.ErrBadRequest(errors.New("missing organization id")))
+				return
+			}
+			orgID, err := primitive.ObjectIDFromHex(raw)
+			if err != nil {
+				render.Render(rw, r, res.ErrBadRequest(err))
+				return
+			}
+			userID, err := primitive.ObjectIDFromHex(claims.Subject)
+			if err != nil {
+				render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+				return
+			}
+			org, err := factory.GetOrganizationDao().FindById(r.Context(), orgID)
+			if err != nil {
+				render.Render(rw, r, res.ErrNotFound(err))
+				return
+			}
+			if !org.HasMember(userID) {
+				render.Render(rw, r, res.Err(errors.New("not a member of this organization"), http.StatusForbidden))
+				return
+			}
+			ctx := context.WithValue(r.Context(), orgIDCtxKey{}, orgID)
+			next.ServeHTTP(rw, r.Clone(ctx))
+		})
+	}
+}
+
+// OrganizationIDFromContext returns the org ID injected by OrganizationCtx.
+func OrganizationIDFromContext(ctx context.Context) (primitive.ObjectID, bool) {
+	orgID, ok := ctx.Value(orgIDCtxKey{}).(primitive.ObjectID)
+	return orgID, ok
+}
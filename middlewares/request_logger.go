@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type loggerCtxKey struct{}
+
+// RequestLogger logs one structured line per request, tagged with the chi
+// request ID and the client's real IP, and stores that same tagged logger
+// in the request context so downstream handlers (and RequireAuth, which
+// adds user_id once it resolves the caller) can log with matching fields.
+func RequestLogger(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			reqLog := log.With(
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+				slog.String("remote_ip", r.RemoteAddr),
+			)
+			ctx := context.WithValue(r.Context(), loggerCtxKey{}, reqLog)
+			ww := middleware.NewWrapResponseWriter(rw, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+			reqLog.Info("request",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", ww.Status()),
+				slog.Duration("duration", time.Since(start)),
+			)
+		})
+	}
+}
+
+// LoggerFromContext returns the request-scoped logger stored by
+// RequestLogger, or the default logger if none was set.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if log, ok := ctx.Value(loggerCtxKey{}).(*slog.Logger); ok {
+		return log
+	}
+	return slog.Default()
+}
+
+func withLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, log)
+}
@@ -0,0 +1,45 @@
+// Package middlewares holds bennu-specific chi middleware (auth, RBAC,
+// org scoping) that sits on top of the generic github.com/knuls/horus/middlewares.
+package middlewares
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/knuls/bennu/auth"
+	"github.com/knuls/horus/res"
+)
+
+type claimsCtxKey struct{}
+
+// RequireAuth validates the Authorization: Bearer <token> header against
+// signer and populates the request context with the resulting claims.
+func RequireAuth(signer *auth.Signer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			raw, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || raw == "" {
+				render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+				return
+			}
+			claims, err := signer.ParseAccessToken(raw)
+			if err != nil {
+				render.Render(rw, r, res.Err(err, http.StatusUnauthorized))
+				return
+			}
+			ctx := context.WithValue(r.Context(), claimsCtxKey{}, claims)
+			ctx = withLogger(ctx, LoggerFromContext(ctx).With(slog.String("user_id", claims.Subject)))
+			next.ServeHTTP(rw, r.Clone(ctx))
+		})
+	}
+}
+
+// ClaimsFromContext returns the claims populated by RequireAuth, if any.
+func ClaimsFromContext(ctx context.Context) (*auth.Claims, bool) {
+	claims, ok := ctx.Value(claimsCtxKey{}).(*auth.Claims)
+	return claims, ok
+}
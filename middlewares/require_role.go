@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/knuls/bennu/auth"
+	"github.com/knuls/bennu/dao"
+	"github.com/knuls/horus/res"
+)
+
+// RequireRole 403s unless the authenticated user (populated by RequireAuth)
+// is an admin or holds role. Admins implicitly satisfy every role check.
+func RequireRole(factory *dao.Factory, role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+				return
+			}
+			user, err := factory.GetUserDao().FindById(r.Context(), claims.Subject)
+			if err != nil {
+				render.Render(rw, r, res.Err(auth.ErrInvalidToken, http.StatusUnauthorized))
+				return
+			}
+			if !user.Admin && !hasRole(user.Roles, role) {
+				render.Render(rw, r, res.Err(errors.New("missing required role: "+role), http.StatusForbidden))
+				return
+			}
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
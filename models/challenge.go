@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Challenge is a short-lived, server-side WebAuthn ceremony session. It is
+// stored in Mongo rather than in-process memory so the register/login
+// begin-then-finish round trip works regardless of which instance behind
+// the load balancer serves the second request.
+type Challenge struct {
+	ID          string    `json:"id" bson:"_id"`
+	UserID      string    `json:"userId" bson:"userId"`
+	Purpose     string    `json:"purpose" bson:"purpose"`
+	SessionData []byte    `json:"-" bson:"sessionData"`
+	ExpiresAt   time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+const (
+	ChallengePurposeRegister = "register"
+	ChallengePurposeLogin    = "login"
+)
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Credential is a single registered WebAuthn/passkey authenticator for a
+// user, as returned by github.com/go-webauthn/webauthn on registration.
+type Credential struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID       primitive.ObjectID `json:"userId" bson:"userId"`
+	CredentialID []byte             `json:"-" bson:"credentialId"`
+	PublicKey    []byte             `json:"-" bson:"publicKey"`
+	AAGUID       []byte             `json:"-" bson:"aaguid"`
+	SignCount    uint32             `json:"-" bson:"signCount"`
+	Transports   []string           `json:"transports" bson:"transports"`
+	CreatedAt    time.Time          `json:"createdAt" bson:"createdAt"`
+}
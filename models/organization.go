@@ -0,0 +1,54 @@
+package models
+
+import (
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleMember = "member"
+)
+
+type Member struct {
+	UserID   primitive.ObjectID `json:"userId" bson:"userId"`
+	Role     string             `json:"role" bson:"role"`
+	JoinedAt time.Time          `json:"joinedAt" bson:"joinedAt"`
+}
+
+type Invite struct {
+	Email     string    `json:"email" bson:"email"`
+	Token     string    `json:"-" bson:"token"`
+	InvitedBy string    `json:"invitedBy" bson:"invitedBy"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+type Organization struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name      string             `json:"name" bson:"name"`
+	OwnerID   primitive.ObjectID `json:"ownerId" bson:"ownerId"`
+	Members   []Member           `json:"members" bson:"members"`
+	Invites   []Invite           `json:"invites" bson:"invites"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+func (o *Organization) Bind(r *http.Request) error {
+	return nil
+}
+
+func (o *Organization) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (o *Organization) HasMember(userID primitive.ObjectID) bool {
+	for _, m := range o.Members {
+		if m.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestOrganizationHasMember(t *testing.T) {
+	member := primitive.NewObjectID()
+	stranger := primitive.NewObjectID()
+	org := &Organization{
+		ID:      primitive.NewObjectID(),
+		Members: []Member{{UserID: member, Role: OrganizationRoleMember}},
+	}
+
+	if !org.HasMember(member) {
+		t.Error("expected HasMember to be true for a member of the organization")
+	}
+	if org.HasMember(stranger) {
+		t.Error("expected HasMember to be false for a user outside the organization")
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshToken is a single rotation in a refresh family: every successful
+// TokenRefresh revokes the presented token and inserts a new one sharing
+// the same FamilyID, so a revoked token re-presented later signals reuse.
+type RefreshToken struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"userId" bson:"userId"`
+	FamilyID  primitive.ObjectID `json:"familyId" bson:"familyId"`
+	TokenHash string             `json:"-" bson:"tokenHash"`
+	IssuedAt  time.Time          `json:"issuedAt" bson:"issuedAt"`
+	ExpiresAt time.Time          `json:"expiresAt" bson:"expiresAt"`
+	RevokedAt *time.Time         `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type User struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Email     string             `json:"email" bson:"email"`
+	Password  string             `json:"password,omitempty" bson:"password"`
+	Verified  bool               `json:"verified" bson:"verified"`
+	Admin     bool               `json:"admin" bson:"admin"`
+	Roles     []string           `json:"roles" bson:"roles"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+func (u *User) Bind(r *http.Request) error {
+	return nil
+}
+
+func (u *User) Render(w http.ResponseWriter, r *http.Request) error {
+	u.Password = ""
+	return nil
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VerificationToken is a single-use, time-limited token proving control of
+// an account's email address. The same mechanism backs the verification
+// link sent on Register and the password-reset flow; Purpose determines
+// what redeeming it is allowed to do.
+type VerificationToken struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"userId" bson:"userId"`
+	TokenHash string             `json:"-" bson:"tokenHash"`
+	Purpose   string             `json:"purpose" bson:"purpose"`
+	ExpiresAt time.Time          `json:"expiresAt" bson:"expiresAt"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+const (
+	VerificationPurposeEmail         = "verify_email"
+	VerificationPurposeResetPassword = "reset_password"
+)
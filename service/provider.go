@@ -0,0 +1,46 @@
+package service
+
+import (
+	"log/slog"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/knuls/bennu/auth"
+	"github.com/knuls/bennu/dao"
+	"github.com/knuls/bennu/mail"
+	"github.com/knuls/horus/validator"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Provider carries the dependencies shared by every handler so main.go wires
+// them up once instead of threading logger/factory/signer/webauthn through
+// each constructor individually.
+type Provider struct {
+	Logger   *slog.Logger
+	Validate *validator.Validator
+	Factory  *dao.Factory
+	Client   *mongo.Client
+	Signer   *auth.Signer
+	WebAuthn *webauthn.WebAuthn
+	Mailer   mail.Mailer
+}
+
+// NewProvider builds a Provider from its already-constructed dependencies.
+func NewProvider(
+	logger *slog.Logger,
+	validate *validator.Validator,
+	factory *dao.Factory,
+	client *mongo.Client,
+	signer *auth.Signer,
+	wa *webauthn.WebAuthn,
+	mailer mail.Mailer,
+) *Provider {
+	return &Provider{
+		Logger:   logger,
+		Validate: validate,
+		Factory:  factory,
+		Client:   client,
+		Signer:   signer,
+		WebAuthn: wa,
+		Mailer:   mailer,
+	}
+}